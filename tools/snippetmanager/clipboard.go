@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// clipboardCommand returns the platform's clipboard-copy command, or an
+// error if none of the usual tools are available.
+func clipboardCommand() (string, []string, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		return "pbcopy", nil, nil
+	case "windows":
+		return "clip", nil, nil
+	default:
+		for _, candidate := range []struct {
+			name string
+			args []string
+		}{
+			{"xclip", []string{"-selection", "clipboard"}},
+			{"xsel", []string{"--clipboard", "--input"}},
+			{"wl-copy", nil},
+		} {
+			if _, err := exec.LookPath(candidate.name); err == nil {
+				return candidate.name, candidate.args, nil
+			}
+		}
+		return "", nil, fmt.Errorf("no clipboard utility found (tried xclip, xsel, wl-copy)")
+	}
+}
+
+// copyToClipboard writes text to the system clipboard using whichever
+// platform clipboard tool is available.
+func copyToClipboard(text string) error {
+	name, args, err := clipboardCommand()
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(text)
+	return cmd.Run()
+}
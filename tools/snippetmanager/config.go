@@ -0,0 +1,129 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// DirectoryConfig is one configured snippet source directory.
+type DirectoryConfig struct {
+	Path     string
+	ReadOnly bool
+}
+
+// Config is the parsed content of ~/.config/micro/snippetmanager.toml.
+type Config struct {
+	Directories []DirectoryConfig
+	// FilterCmd is the external fuzzy-filter binary used by the interactive
+	// selector, e.g. "fzf" (the default) or "peco".
+	FilterCmd string
+
+	// AutoSync pushes to the configured remote after every Add/Delete.
+	AutoSync bool
+
+	Gist   GistConfig
+	GitLab GitLabConfig
+}
+
+// GistConfig configures syncing against a GitHub Gist.
+type GistConfig struct {
+	AccessToken string
+	GistID      string
+	Public      bool
+}
+
+// GitLabConfig configures syncing against a GitLab Snippet.
+type GitLabConfig struct {
+	URL        string
+	Token      string
+	ID         string
+	Visibility string
+}
+
+// configPath returns the path to snippetmanager.toml inside the micro
+// config directory.
+func configPath() string {
+	home := os.Getenv("HOME")
+	return filepath.Join(home, ".config", "micro", "snippetmanager.toml")
+}
+
+/*
+LoadConfig reads snippetmanager.toml and returns the configured snippet
+directories.
+
+If the file doesn't exist, a single default directory
+(~/.config/micro/snippets) is returned so the tool works out of the box.
+*/
+func LoadConfig() (*Config, error) {
+	data, err := os.ReadFile(configPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			home := os.Getenv("HOME")
+			return &Config{
+				Directories: []DirectoryConfig{
+					{Path: filepath.Join(home, ".config", "micro", "snippets")},
+				},
+			}, nil
+		}
+		return nil, err
+	}
+
+	doc, err := parseTOML(data)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{
+		FilterCmd: tomlString(doc.Top, "filter_cmd"),
+		AutoSync:  tomlBool(doc.Top, "auto_sync"),
+	}
+	for _, entry := range doc.Tables["directories"] {
+		cfg.Directories = append(cfg.Directories, DirectoryConfig{
+			Path:     expandHome(tomlString(entry, "path")),
+			ReadOnly: tomlBool(entry, "readonly"),
+		})
+	}
+
+	if gist, ok := doc.Top["Gist"].(map[string]interface{}); ok {
+		cfg.Gist = GistConfig{
+			AccessToken: tomlString(gist, "access_token"),
+			GistID:      tomlString(gist, "gist_id"),
+			Public:      tomlBool(gist, "public"),
+		}
+	}
+	if cfg.Gist.AccessToken == "" {
+		cfg.Gist.AccessToken = os.Getenv("GIST_TOKEN")
+	}
+
+	if gitlab, ok := doc.Top["GitLab"].(map[string]interface{}); ok {
+		cfg.GitLab = GitLabConfig{
+			URL:        tomlString(gitlab, "url"),
+			Token:      tomlString(gitlab, "token"),
+			ID:         tomlString(gitlab, "id"),
+			Visibility: tomlString(gitlab, "visibility"),
+		}
+	}
+	if cfg.GitLab.Token == "" {
+		cfg.GitLab.Token = os.Getenv("GITLAB_TOKEN")
+	}
+
+	if len(cfg.Directories) == 0 {
+		home := os.Getenv("HOME")
+		cfg.Directories = []DirectoryConfig{
+			{Path: filepath.Join(home, ".config", "micro", "snippets")},
+		}
+	}
+
+	return cfg, nil
+}
+
+// expandHome expands a leading "~" into the user's home directory.
+func expandHome(path string) string {
+	if path == "~" {
+		return os.Getenv("HOME")
+	}
+	if len(path) > 1 && path[:2] == "~/" {
+		return filepath.Join(os.Getenv("HOME"), path[2:])
+	}
+	return path
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const defaultScope = "global"
+
+// vscodeSnippet is one entry in a VS Code *.code-snippets file.
+type vscodeSnippet struct {
+	Prefix      string   `json:"prefix"`
+	Scope       string   `json:"scope,omitempty"`
+	Body        []string `json:"body"`
+	Description string   `json:"description,omitempty"`
+}
+
+/*
+Export writes every loaded snippet into outDir in the given format:
+
+  - "micro": one <language>.snippets file per language, in the
+    `snippet <name>` / body / `endsnippet` layout micro's snippets plugin
+    reads from its plugin snippets directory.
+  - "vscode": one <name>.code-snippets file per snippet, VS Code's
+    prefix/scope/body/description JSON layout.
+
+Language/scope falls back to "global" when a snippet has none set.
+*/
+func (sm *SnippetManager) Export(outDir, format string) error {
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return err
+	}
+
+	switch format {
+	case "micro":
+		return sm.exportMicro(outDir)
+	case "vscode":
+		return sm.exportVSCode(outDir)
+	default:
+		return fmt.Errorf("unsupported export format %q (want \"micro\" or \"vscode\")", format)
+	}
+}
+
+func (sm *SnippetManager) exportMicro(outDir string) error {
+	byLanguage := make(map[string][]*Snippet)
+	for _, name := range sm.sortedNames() {
+		s := sm.Snippets[name]
+		lang := s.Language
+		if lang == "" {
+			lang = defaultScope
+		}
+		byLanguage[lang] = append(byLanguage[lang], s)
+	}
+
+	for lang, snippets := range byLanguage {
+		var out strings.Builder
+		for _, s := range snippets {
+			out.WriteString("snippet " + s.Name + "\n")
+			for _, line := range strings.Split(s.Content, "\n") {
+				out.WriteString("\t" + line + "\n")
+			}
+			out.WriteString("endsnippet\n\n")
+		}
+
+		path := filepath.Join(outDir, snippetFilename(lang, ".snippets"))
+		if err := os.WriteFile(path, []byte(out.String()), 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (sm *SnippetManager) exportVSCode(outDir string) error {
+	for _, name := range sm.sortedNames() {
+		s := sm.Snippets[name]
+		scope := s.Language
+		if scope == "" {
+			scope = defaultScope
+		}
+
+		entry := map[string]vscodeSnippet{
+			s.Name: {
+				Prefix:      s.Name,
+				Scope:       scope,
+				Body:        strings.Split(s.Content, "\n"),
+				Description: s.Description,
+			},
+		}
+
+		data, err := json.MarshalIndent(entry, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		path := filepath.Join(outDir, snippetFilename(name, ".code-snippets"))
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
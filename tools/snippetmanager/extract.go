@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	defaultBeginMarker = "snippet:begin"
+	defaultEndMarker   = "snippet:end"
+)
+
+/*
+Extract walks each of paths (files or directories), pulling out snippets
+delimited by beginMarker/endMarker (e.g. "// snippet:begin name=foo" /
+"// snippet:end") and writing each captured block into its own file in the
+first writable configured directory - the same layout SyncDownload uses, so
+re-running Extract after editing source keeps the store in sync.
+
+When extFilter is non-empty, only files with that extension (e.g. ".go")
+are scanned. It returns the number of snippets extracted.
+*/
+func (sm *SnippetManager) Extract(paths []string, extFilter, beginMarker, endMarker string) (int, error) {
+	if beginMarker == "" {
+		beginMarker = defaultBeginMarker
+	}
+	if endMarker == "" {
+		endMarker = defaultEndMarker
+	}
+
+	dir := sm.firstWritableDirectory()
+	if dir == nil {
+		return 0, fmt.Errorf("no writable snippet directory configured")
+	}
+
+	count := 0
+	for _, root := range paths {
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				return nil
+			}
+			if extFilter != "" && filepath.Ext(path) != extFilter {
+				return nil
+			}
+
+			snippets, err := extractFile(path, beginMarker, endMarker)
+			if err != nil {
+				return err
+			}
+			for _, s := range snippets {
+				target := filepath.Join(dir.Path, snippetFilename(s.Name, ".toml"))
+				if err := os.WriteFile(target, []byte(s.encode()), 0644); err != nil {
+					return err
+				}
+				s.SourceFile = target
+				sm.Snippets[s.Name] = s
+				count++
+			}
+			return nil
+		})
+		if err != nil {
+			return count, err
+		}
+	}
+
+	return count, nil
+}
+
+// extractFile scans a single file for marker-delimited snippets.
+func extractFile(path, beginMarker, endMarker string) ([]*Snippet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(string(data), "\n")
+
+	var snippets []*Snippet
+	for i := 0; i < len(lines); i++ {
+		if !strings.Contains(lines[i], beginMarker) {
+			continue
+		}
+
+		name := markerName(lines[i])
+		if name == "" {
+			fmt.Fprintf(os.Stderr, "warning: %s:%d: %s with no name=... attribute, skipping\n", path, i+1, beginMarker)
+			continue
+		}
+
+		end := -1
+		for j := i + 1; j < len(lines); j++ {
+			if strings.Contains(lines[j], endMarker) {
+				end = j
+				break
+			}
+		}
+
+		var block []string
+		if end == -1 {
+			fmt.Fprintf(os.Stderr, "warning: %s:%d: unterminated %s for '%s', extracting to end of file\n", path, i+1, beginMarker, name)
+			block = lines[i+1:]
+			i = len(lines)
+		} else {
+			block = lines[i+1 : end]
+			i = end
+		}
+
+		snippets = append(snippets, &Snippet{
+			Name:    name,
+			Content: stripCommonIndent(block),
+		})
+	}
+
+	return snippets, nil
+}
+
+// markerName pulls the value of name=... out of a marker line, stopping at
+// trailing whitespace or a C-style comment close ("*/") but leaving other
+// characters - notably '/', common in namespaced snippet names - intact.
+func markerName(line string) string {
+	idx := strings.Index(line, "name=")
+	if idx < 0 {
+		return ""
+	}
+	rest := strings.TrimSpace(line[idx+len("name="):])
+
+	end := strings.IndexAny(rest, " \t")
+	if star := strings.Index(rest, "*/"); star >= 0 && (end < 0 || star < end) {
+		end = star
+	}
+	if end >= 0 {
+		rest = rest[:end]
+	}
+
+	return strings.Trim(rest, `"'`)
+}
+
+// stripCommonIndent removes the longest whitespace prefix shared by every
+// non-blank line, then joins the block back into a single string.
+func stripCommonIndent(lines []string) string {
+	minIndent := -1
+	for _, l := range lines {
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+		indent := len(l) - len(strings.TrimLeft(l, " \t"))
+		if minIndent == -1 || indent < minIndent {
+			minIndent = indent
+		}
+	}
+
+	if minIndent <= 0 {
+		return strings.Join(lines, "\n")
+	}
+
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		if len(l) >= minIndent {
+			out[i] = l[minIndent:]
+		} else {
+			out[i] = strings.TrimLeft(l, " \t")
+		}
+	}
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+/*
+Import reads snippets out of inDir in the given format and registers them
+in memory (writing them into the first writable configured directory),
+reversing Export:
+
+  - "micro": parses every *.snippets file's `snippet name` / body /
+    `endsnippet` blocks, taking the language from the filename.
+  - "vscode": parses every *.code-snippets file's prefix/scope/body/
+    description entries.
+
+It returns the number of snippets imported.
+*/
+func (sm *SnippetManager) Import(inDir, format string) (int, error) {
+	dir := sm.firstWritableDirectory()
+	if dir == nil {
+		return 0, fmt.Errorf("no writable snippet directory configured")
+	}
+
+	var snippets []*Snippet
+	var err error
+	switch format {
+	case "micro":
+		snippets, err = importMicro(inDir)
+	case "vscode":
+		snippets, err = importVSCode(inDir)
+	default:
+		return 0, fmt.Errorf("unsupported import format %q (want \"micro\" or \"vscode\")", format)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	for _, s := range snippets {
+		path := filepath.Join(dir.Path, snippetFilename(s.Name, ".toml"))
+		if err := os.WriteFile(path, []byte(s.encode()), 0644); err != nil {
+			return 0, err
+		}
+		s.SourceFile = path
+		sm.Snippets[s.Name] = s
+	}
+
+	return len(snippets), nil
+}
+
+func importMicro(inDir string) ([]*Snippet, error) {
+	entries, err := os.ReadDir(inDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var snippets []*Snippet
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".snippets") {
+			continue
+		}
+		language := strings.TrimSuffix(entry.Name(), ".snippets")
+
+		data, err := os.ReadFile(filepath.Join(inDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		lines := strings.Split(string(data), "\n")
+		for i := 0; i < len(lines); i++ {
+			if !strings.HasPrefix(lines[i], "snippet ") {
+				continue
+			}
+			name := strings.TrimSpace(strings.TrimPrefix(lines[i], "snippet "))
+
+			// Body lines are always tab-indented by exportMicro, so the
+			// unindented "endsnippet" literal is unambiguous even when a
+			// snippet's own content contains that word on an indented line.
+			var body []string
+			j := i + 1
+			for ; j < len(lines) && lines[j] != "endsnippet"; j++ {
+				body = append(body, strings.TrimPrefix(lines[j], "\t"))
+			}
+			i = j
+
+			snippets = append(snippets, &Snippet{
+				Name:     name,
+				Content:  strings.Join(body, "\n"),
+				Language: language,
+			})
+		}
+	}
+
+	return snippets, nil
+}
+
+func importVSCode(inDir string) ([]*Snippet, error) {
+	entries, err := os.ReadDir(inDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var snippets []*Snippet
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".code-snippets") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(inDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		var parsed map[string]vscodeSnippet
+		if err := json.Unmarshal(data, &parsed); err != nil {
+			return nil, fmt.Errorf("%s: %v", entry.Name(), err)
+		}
+
+		for name, v := range parsed {
+			snippets = append(snippets, &Snippet{
+				Name:        name,
+				Content:     strings.Join(v.Body, "\n"),
+				Description: v.Description,
+				Language:    v.Scope,
+			})
+		}
+	}
+
+	return snippets, nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// defaultFilterCmd is used when the user hasn't configured filter_cmd.
+const defaultFilterCmd = "fzf"
+
+/*
+SelectInteractive pipes the sorted snippet list into the configured filter
+command (fzf by default, overridable via filter_cmd) and returns the snippet
+the user picked.
+
+When the filter binary isn't on PATH, it falls back to printing the plain
+snippet list via List() and returns nil so callers can stop gracefully.
+*/
+func (sm *SnippetManager) SelectInteractive() (*Snippet, error) {
+	filterCmd := sm.Config.FilterCmd
+	if filterCmd == "" {
+		filterCmd = defaultFilterCmd
+	}
+
+	binary, err := exec.LookPath(filterCmd)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "'%s' not found on PATH, falling back to plain list\n", filterCmd)
+		sm.List()
+		return nil, nil
+	}
+
+	names := sm.sortedNames()
+	if len(names) == 0 {
+		fmt.Println("No snippets saved.")
+		return nil, nil
+	}
+
+	args := filterArgs(filterCmd)
+	cmd := exec.Command(binary, args...)
+	cmd.Stdin = strings.NewReader(strings.Join(names, "\n"))
+	cmd.Stderr = os.Stderr
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("running %s: %w", filterCmd, err)
+	}
+
+	selected := strings.TrimSpace(out.String())
+	if selected == "" {
+		return nil, nil
+	}
+
+	snippet, ok := sm.Snippets[selected]
+	if !ok {
+		return nil, fmt.Errorf("filter returned unknown snippet '%s'", selected)
+	}
+	return snippet, nil
+}
+
+// filterArgs builds the filter command's arguments, adding a content
+// preview pane when the filter is fzf (peco and others don't support it).
+func filterArgs(filterCmd string) []string {
+	if filterCmd != "fzf" {
+		return nil
+	}
+	// fzf substitutes {} with the highlighted item's literal text before
+	// handing this string to $SHELL -c, so it must be quoted too - an
+	// unquoted {} lets a snippet name with shell metacharacters execute
+	// arbitrary commands just by being highlighted in the picker.
+	previewCmd := fmt.Sprintf("%s show '{}'", shellQuote(os.Args[0]))
+	return []string{"--preview", previewCmd}
+}
+
+// shellQuote wraps s in single quotes for safe use inside a shell command
+// string, escaping any embedded single quotes.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// openInMicro writes a snippet's content to a temp file and opens it in
+// micro, inheriting the current process's stdio.
+func openInMicro(snippet *Snippet) error {
+	tmp, err := os.CreateTemp("", "snippet-*.txt")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(snippet.Content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	cmd := exec.Command("micro", tmp.Name())
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,146 @@
+package main
+
+import "strings"
+
+// Placeholder is a named, optionally-defaulted parameter embedded in a
+// snippet's content, e.g. <port=8080> or ${host:localhost}.
+type Placeholder struct {
+	Name    string
+	Default string
+}
+
+/*
+ExtractPlaceholders scans content for pet-style placeholders in either of
+two forms:
+
+	<name=default>
+	${name:default}
+
+The default is optional in both forms (<name>, ${name}). A backslash
+escapes the opening delimiter (\< or \$) so literal angle brackets and
+dollar signs can appear in snippet content. Placeholders are returned in
+first-occurrence order, deduplicated by name.
+*/
+func ExtractPlaceholders(content string) []Placeholder {
+	var placeholders []Placeholder
+	seen := make(map[string]bool)
+
+	runes := []rune(content)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == '\\' && i+1 < len(runes) {
+			i++
+			continue
+		}
+
+		var p *Placeholder
+		var next int
+		switch {
+		case runes[i] == '<':
+			p, next = parseAngleBracket(runes, i)
+		case runes[i] == '$' && i+1 < len(runes) && runes[i+1] == '{':
+			p, next = parseBraceForm(runes, i)
+		}
+
+		if p != nil {
+			if !seen[p.Name] {
+				seen[p.Name] = true
+				placeholders = append(placeholders, *p)
+			}
+			i = next
+		}
+	}
+
+	return placeholders
+}
+
+// parseAngleBracket parses a <name=default> placeholder starting at the
+// '<' rune index i. It returns nil if the span isn't a well-formed
+// placeholder (e.g. a stray '<' with no matching '>').
+func parseAngleBracket(runes []rune, i int) (*Placeholder, int) {
+	end := indexRune(runes, '>', i+1)
+	if end < 0 {
+		return nil, i
+	}
+	body := string(runes[i+1 : end])
+	if body == "" || strings.ContainsAny(body, "<>") {
+		return nil, i
+	}
+	name, def := splitDefault(body, "=")
+	return &Placeholder{Name: name, Default: def}, end
+}
+
+// parseBraceForm parses a ${name:default} placeholder starting at the '$'
+// rune index i.
+func parseBraceForm(runes []rune, i int) (*Placeholder, int) {
+	end := indexRune(runes, '}', i+2)
+	if end < 0 {
+		return nil, i
+	}
+	body := string(runes[i+2 : end])
+	if body == "" {
+		return nil, i
+	}
+	name, def := splitDefault(body, ":")
+	return &Placeholder{Name: name, Default: def}, end
+}
+
+// splitDefault splits "name<sep>default" on the first occurrence of sep,
+// so a default value may itself contain sep without being cut short.
+func splitDefault(body, sep string) (name, def string) {
+	idx := strings.Index(body, sep)
+	if idx < 0 {
+		return body, ""
+	}
+	return body[:idx], body[idx+len(sep):]
+}
+
+func indexRune(runes []rune, target rune, from int) int {
+	for i := from; i < len(runes); i++ {
+		if runes[i] == target {
+			return i
+		}
+	}
+	return -1
+}
+
+/*
+Substitute replaces every placeholder in content with its value from
+values, falling back to the placeholder's own default when the name is
+absent from values. Escaped delimiters (\< and \$) are unescaped in the
+output.
+*/
+func Substitute(content string, values map[string]string) string {
+	var out strings.Builder
+	runes := []rune(content)
+
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == '\\' && i+1 < len(runes) && (runes[i+1] == '<' || runes[i+1] == '$') {
+			out.WriteRune(runes[i+1])
+			i++
+			continue
+		}
+
+		var p *Placeholder
+		var next int
+		switch {
+		case runes[i] == '<':
+			p, next = parseAngleBracket(runes, i)
+		case runes[i] == '$' && i+1 < len(runes) && runes[i+1] == '{':
+			p, next = parseBraceForm(runes, i)
+		}
+
+		if p != nil {
+			if v, ok := values[p.Name]; ok {
+				out.WriteString(v)
+			} else {
+				out.WriteString(p.Default)
+			}
+			i = next
+			continue
+		}
+
+		out.WriteRune(runes[i])
+	}
+
+	return out.String()
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// parseParamFlags pulls every "--param key=value" pair out of args, returning
+// the collected values plus args with those flags removed.
+func parseParamFlags(args []string) (map[string]string, []string) {
+	values := make(map[string]string)
+	rest := args[:0:0]
+
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--param" && i+1 < len(args) {
+			kv := args[i+1]
+			if idx := strings.Index(kv, "="); idx >= 0 {
+				values[kv[:idx]] = kv[idx+1:]
+			}
+			i++
+			continue
+		}
+		rest = append(rest, args[i])
+	}
+
+	return values, rest
+}
+
+// promptForPlaceholders asks on stdin for any placeholder not already present
+// in provided, showing its default (if any) and accepting it on empty input.
+func promptForPlaceholders(placeholders []Placeholder, provided map[string]string) (map[string]string, error) {
+	values := make(map[string]string, len(provided))
+	for k, v := range provided {
+		values[k] = v
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	for _, p := range placeholders {
+		if _, ok := values[p.Name]; ok {
+			continue
+		}
+
+		if p.Default != "" {
+			fmt.Printf("%s [%s]: ", p.Name, p.Default)
+		} else {
+			fmt.Printf("%s: ", p.Name)
+		}
+
+		line, err := reader.ReadString('\n')
+		if err != nil && line == "" {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			line = p.Default
+		}
+		values[p.Name] = line
+	}
+
+	return values, nil
+}
+
+/*
+Fill resolves every placeholder in the named snippet's content, prompting
+for any value not supplied in provided, and returns the substituted text.
+It performs no execution even if the snippet is marked executable.
+*/
+func (sm *SnippetManager) Fill(name string, provided map[string]string) (string, error) {
+	snippet, ok := sm.Snippets[name]
+	if !ok {
+		return "", fmt.Errorf("snippet '%s' not found", name)
+	}
+
+	values, err := promptForPlaceholders(ExtractPlaceholders(snippet.Content), provided)
+	if err != nil {
+		return "", err
+	}
+	return Substitute(snippet.Content, values), nil
+}
+
+/*
+Run fills in the named snippet's placeholders and either prints the result
+or, when the snippet is marked executable = true, runs it via $SHELL -c with
+stdio inherited from the current process. The returned error is an
+*exec.ExitError when the executed snippet exits non-zero, so callers can
+propagate its exit code.
+*/
+func (sm *SnippetManager) Run(name string, provided map[string]string) error {
+	snippet, ok := sm.Snippets[name]
+	if !ok {
+		return fmt.Errorf("snippet '%s' not found", name)
+	}
+
+	filled, err := sm.Fill(name, provided)
+	if err != nil {
+		return err
+	}
+
+	if !snippet.Executable {
+		fmt.Println(filled)
+		return nil
+	}
+
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = "/bin/sh"
+	}
+
+	cmd := exec.Command(shell, "-c", filled)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Snippet is a single stored snippet and its pet-style metadata.
+type Snippet struct {
+	Name        string
+	Content     string
+	Description string
+	Tags        []string
+	Language    string
+	Output      string
+	// Executable marks a snippet that `run` should hand to $SHELL -c rather
+	// than print.
+	Executable bool
+
+	// SourceFile is the TOML file this snippet was loaded from. It is unset
+	// for snippets that have not yet been saved.
+	SourceFile string
+	// ReadOnly marks snippets loaded from a directory the config flags as
+	// read-only; Delete refuses to touch them.
+	ReadOnly bool
+}
+
+// loadSnippetFile parses a single *.toml snippet file, returning each
+// `[[snippet]]` entry it contains.
+func loadSnippetFile(path string, readOnly bool) ([]*Snippet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := parseTOML(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+
+	entries := doc.Tables["snippet"]
+	snippets := make([]*Snippet, 0, len(entries))
+	for _, e := range entries {
+		name := tomlString(e, "name")
+		if name == "" {
+			return nil, fmt.Errorf("%s: snippet entry missing required 'name' field", path)
+		}
+		snippets = append(snippets, &Snippet{
+			Name:        name,
+			Content:     tomlString(e, "content"),
+			Description: tomlString(e, "description"),
+			Tags:        tomlStringSlice(e, "tags"),
+			Language:    tomlString(e, "language"),
+			Output:      tomlString(e, "output"),
+			Executable:  tomlBool(e, "executable"),
+			SourceFile:  path,
+			ReadOnly:    readOnly,
+		})
+	}
+	return snippets, nil
+}
+
+// encode renders the snippet as a `[[snippet]]` TOML block.
+func (s *Snippet) encode() string {
+	out := "[[snippet]]\n"
+	out += fmt.Sprintf("name = %s\n", quoteTOMLString(s.Name))
+	out += fmt.Sprintf("content = %s\n", quoteTOMLString(s.Content))
+	if s.Description != "" {
+		out += fmt.Sprintf("description = %s\n", quoteTOMLString(s.Description))
+	}
+	if len(s.Tags) > 0 {
+		out += fmt.Sprintf("tags = %s\n", quoteTOMLStringArray(s.Tags))
+	}
+	if s.Language != "" {
+		out += fmt.Sprintf("language = %s\n", quoteTOMLString(s.Language))
+	}
+	if s.Output != "" {
+		out += fmt.Sprintf("output = %s\n", quoteTOMLString(s.Output))
+	}
+	if s.Executable {
+		out += "executable = true\n"
+	}
+	return out
+}
+
+// timeBasedFilename generates a snippet filename from the given timestamp,
+// used by Add when the caller doesn't specify a target file.
+func timeBasedFilename(timestamp string) string {
+	return "snippet-" + timestamp + ".toml"
+}
+
+// snippetFilename derives a safe on-disk filename for a snippet name (or
+// language) that ultimately comes from sync, extract, import, or a loaded
+// snippet file's own metadata, so a crafted value like "../../etc/passwd"
+// can't escape the target directory whether it's being written or exported.
+func snippetFilename(name, suffix string) string {
+	return filepath.Base(filepath.Clean(name)) + suffix
+}
@@ -2,8 +2,9 @@
 Package main implements a snippet manager for the micro editor.
 
 This tool allows users to manage code snippets that can be easily inserted
-into their editing workflow. Snippets are stored in JSON format in micro's
-configuration directory and can be listed, added, viewed, and deleted.
+into their editing workflow. Snippets live as pet-style TOML files across one
+or more configured directories (see ~/.config/micro/snippetmanager.toml) and
+can be listed, added, viewed, and deleted.
 
 The manager follows the UNIX philosophy of doing one thing well and integrates
 seamlessly with micro's ecosystem.
@@ -12,74 +13,97 @@ package main
 
 import (
 	"bufio"
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 )
 
 // SnippetManager handles the storage and management of code snippets.
-// It maintains snippets in memory and persists them to a JSON file.
+// It maintains snippets in memory, merged from every configured directory,
+// and persists new ones to the first configured directory.
 type SnippetManager struct {
-	Filepath string            // Path to the snippets JSON file
-	Snippets map[string]string // In-memory storage of snippets (name -> content)
+	Config   *Config
+	Snippets map[string]*Snippet // name -> snippet, merged across directories
 }
 
 /*
 NewSnippetManager creates a new SnippetManager instance.
 
-It initialises the configuration directory at ~/.config/micro if it doesn't exist
-and sets up the path for the snippets JSON file. The in-memory snippet storage
-is initialised as an empty map.
+It loads the directory configuration from ~/.config/micro/snippetmanager.toml
+(falling back to a single default directory when absent) and ensures each
+writable directory exists on disk.
 */
-func NewSnippetManager() *SnippetManager {
-	home := os.Getenv("HOME")
-	configDir := filepath.Join(home, ".config", "micro")
-	
-	// Create config directory if it doesn't exist
-	if _, err := os.Stat(configDir); os.IsNotExist(err) {
-		os.MkdirAll(configDir, 0755)
-	}
-	
-	return &SnippetManager{
-		Filepath: filepath.Join(configDir, "snippets.json"),
-		Snippets: make(map[string]string),
+func NewSnippetManager() (*SnippetManager, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, err
 	}
+
+	for _, dir := range cfg.Directories {
+		if dir.ReadOnly {
+			continue
+		}
+		if _, err := os.Stat(dir.Path); os.IsNotExist(err) {
+			os.MkdirAll(dir.Path, 0755)
+		}
+	}
+
+	return &SnippetManager{
+		Config:   cfg,
+		Snippets: make(map[string]*Snippet),
+	}, nil
 }
 
 /*
-Load reads snippets from the JSON file into memory.
+Load scans every configured directory for *.toml snippet files and merges
+their entries into memory, keyed by name.
 
-Returns nil if the file doesn't exist (initial empty snippets), or an error
-if there are issues reading or parsing the file.
+Directories are scanned in configuration order, and the first directory to
+define a given name wins; later, colliding definitions are skipped with a
+warning printed to stderr. Missing directories are treated as empty rather
+than an error. A single *.toml file that fails to parse is skipped with a
+warning rather than aborting the whole load, so one bad snippet file can't
+lock the rest of the store out of every subcommand.
 */
 func (sm *SnippetManager) Load() error {
-	data, err := ioutil.ReadFile(sm.Filepath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			sm.Snippets = make(map[string]string)
-			return nil
+	sm.Snippets = make(map[string]*Snippet)
+
+	for _, dir := range sm.Config.Directories {
+		entries, err := os.ReadDir(dir.Path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
 		}
-		return err
-	}
-	return json.Unmarshal(data, &sm.Snippets)
-}
 
-/*
-Save writes the in-memory snippets to the JSON file.
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".toml") {
+				continue
+			}
 
-The file is created with 0644 permissions (read/write for owner, read for others).
-The JSON output is pretty-printed with 2-space indentation.
-*/
-func (sm *SnippetManager) Save() error {
-	data, err := json.MarshalIndent(sm.Snippets, "", "  ")
-	if err != nil {
-		return err
+			path := filepath.Join(dir.Path, entry.Name())
+			snippets, err := loadSnippetFile(path, dir.ReadOnly)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "warning: skipping unparseable snippet file %s: %v\n", path, err)
+				continue
+			}
+
+			for _, s := range snippets {
+				if existing, ok := sm.Snippets[s.Name]; ok {
+					fmt.Fprintf(os.Stderr, "warning: snippet '%s' in %s shadowed by earlier definition in %s\n", s.Name, path, existing.SourceFile)
+					continue
+				}
+				sm.Snippets[s.Name] = s
+			}
+		}
 	}
-	return ioutil.WriteFile(sm.Filepath, data, 0644)
+
+	return nil
 }
 
 /*
@@ -93,31 +117,42 @@ func (sm *SnippetManager) List() {
 		fmt.Println("No snippets saved.")
 		return
 	}
-	
-	// Sort snippet names alphabetically
+
+	keys := sm.sortedNames()
+
+	fmt.Println("Saved snippets:")
+	for _, k := range keys {
+		fmt.Println("- " + k)
+	}
+}
+
+// sortedNames returns the names of all loaded snippets in alphabetical order.
+func (sm *SnippetManager) sortedNames() []string {
 	keys := make([]string, 0, len(sm.Snippets))
 	for k := range sm.Snippets {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
-	
-	fmt.Println("Saved snippets:")
-	for _, k := range keys {
-		fmt.Println("- " + k)
-	}
+	return keys
 }
 
 /*
 Add creates a new snippet with the given name.
 
 The snippet content is read from stdin until an empty line is encountered.
-The new snippet is added to memory and immediately persisted to disk.
+The snippet is written into its own file inside the first configured
+directory, using a time-based filename, and is added to memory.
 */
 func (sm *SnippetManager) Add(name string) error {
+	target := sm.firstWritableDirectory()
+	if target == nil {
+		return fmt.Errorf("no writable snippet directory configured")
+	}
+
 	fmt.Println("Paste your snippet. End input with an empty line:")
 	scanner := bufio.NewScanner(os.Stdin)
 	lines := []string{}
-	
+
 	for scanner.Scan() {
 		line := scanner.Text()
 		if line == "" {
@@ -125,14 +160,36 @@ func (sm *SnippetManager) Add(name string) error {
 		}
 		lines = append(lines, line)
 	}
-	
+
 	if err := scanner.Err(); err != nil {
 		return err
 	}
-	
-	snippet := strings.Join(lines, "\n")
+
+	snippet := &Snippet{
+		Name:    name,
+		Content: strings.Join(lines, "\n"),
+	}
+
+	path := filepath.Join(target.Path, timeBasedFilename(time.Now().Format("20060102150405")))
+	if err := os.WriteFile(path, []byte(snippet.encode()), 0644); err != nil {
+		return err
+	}
+	snippet.SourceFile = path
+
 	sm.Snippets[name] = snippet
-	return sm.Save()
+	sm.autoSync()
+	return nil
+}
+
+// firstWritableDirectory returns the first configured directory that isn't
+// marked read-only, or nil if none is configured.
+func (sm *SnippetManager) firstWritableDirectory() *DirectoryConfig {
+	for i := range sm.Config.Directories {
+		if !sm.Config.Directories[i].ReadOnly {
+			return &sm.Config.Directories[i]
+		}
+	}
+	return nil
 }
 
 /*
@@ -147,21 +204,31 @@ func (sm *SnippetManager) Show(name string) {
 		fmt.Printf("Snippet '%s' not found.\n", name)
 		return
 	}
-	fmt.Println(snippet)
+	fmt.Println(snippet.Content)
 }
 
 /*
 Delete removes a snippet with the given name.
 
-Returns an error if the snippet doesn't exist. On success, the change is
-immediately persisted to disk.
+Returns an error if the snippet doesn't exist, or if it was sourced from a
+read-only directory. On success, the snippet's source file is removed from
+disk.
 */
 func (sm *SnippetManager) Delete(name string) error {
-	if _, ok := sm.Snippets[name]; !ok {
-		return fmt.Errorf("Snippet '%s' not found", name)
+	snippet, ok := sm.Snippets[name]
+	if !ok {
+		return fmt.Errorf("snippet '%s' not found", name)
+	}
+	if snippet.ReadOnly {
+		return fmt.Errorf("snippet '%s' is read-only (sourced from %s)", name, snippet.SourceFile)
+	}
+
+	if err := os.Remove(snippet.SourceFile); err != nil {
+		return err
 	}
 	delete(sm.Snippets, name)
-	return sm.Save()
+	sm.autoSync()
+	return nil
 }
 
 /*
@@ -174,9 +241,61 @@ func printUsage() {
 	fmt.Println("Usage:")
 	fmt.Println("  snippetmanager list                 # List all snippets")
 	fmt.Println("  snippetmanager add <name>           # Add snippet (input from stdin)")
-	fmt.Println("  snippetmanager show <name>          # Show snippet content")
-	fmt.Println("  snippetmanager delete <name>        # Delete snippet")
-	fmt.Println("\nSnippets are stored in ~/.config/micro/snippets.json")
+	fmt.Println("  snippetmanager show <name>|--filter [--fill] [--param k=v ...] # Show snippet content")
+	fmt.Println("  snippetmanager delete <name>|--filter # Delete snippet")
+	fmt.Println("  snippetmanager interactive [--copy|--edit] # Pick a snippet with fzf/peco")
+	fmt.Println("  snippetmanager run <name> [--param k=v ...] # Fill placeholders and run/print")
+	fmt.Println("  snippetmanager sync upload|download [--force] # Sync with Gist/GitLab")
+	fmt.Println("  snippetmanager extract <path>... [--extension .go] # Pull snippets from annotated source")
+	fmt.Println("  snippetmanager export --format=micro|vscode <outdir> # Export to editor-consumable formats")
+	fmt.Println("  snippetmanager import --format=micro|vscode <indir>  # Import from editor-consumable formats")
+	fmt.Println("\nSnippet directories are configured in ~/.config/micro/snippetmanager.toml")
+}
+
+// hasFlag reports whether flag is present among args, and returns args with
+// it removed.
+func hasFlag(args []string, flag string) (bool, []string) {
+	out := args[:0:0]
+	found := false
+	for _, a := range args {
+		if a == flag {
+			found = true
+			continue
+		}
+		out = append(out, a)
+	}
+	return found, out
+}
+
+// formatFlag extracts a "--format=value" flag from args, returning the
+// value and args with that flag removed.
+func formatFlag(args []string) (string, []string) {
+	out := args[:0:0]
+	format := ""
+	for _, a := range args {
+		if strings.HasPrefix(a, "--format=") {
+			format = strings.TrimPrefix(a, "--format=")
+			continue
+		}
+		out = append(out, a)
+	}
+	return format, out
+}
+
+// stringFlag extracts "flag value" from args, returning the value (or def
+// if absent) and args with the flag and its value removed.
+func stringFlag(args []string, flag, def string) (string, []string) {
+	out := args[:0:0]
+	value := def
+	for i := 0; i < len(args); i++ {
+		if args[i] == flag && i+1 < len(args) {
+			value = args[i+1]
+			i++
+			continue
+		}
+		out = append(out, args[i])
+	}
+	return value, out
 }
 
 /*
@@ -186,9 +305,13 @@ It handles command-line arguments and delegates to the appropriate SnippetManage
 methods. Invalid commands or missing arguments result in usage instructions.
 */
 func main() {
-	sm := NewSnippetManager()
-	err := sm.Load()
+	sm, err := NewSnippetManager()
 	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error initialising snippet manager: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := sm.Load(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading snippets: %v\n", err)
 		os.Exit(1)
 	}
@@ -216,24 +339,173 @@ func main() {
 		}
 		fmt.Printf("Snippet '%s' added.\n", name)
 	case "show":
-		if len(os.Args) < 3 {
+		rest := os.Args[2:]
+		useFilter, rest := hasFlag(rest, "--filter")
+		fill, rest := hasFlag(rest, "--fill")
+		params, rest := parseParamFlags(rest)
+
+		name := ""
+		if useFilter {
+			snippet, err := sm.SelectInteractive()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error selecting snippet: %v\n", err)
+				os.Exit(1)
+			}
+			if snippet == nil {
+				return
+			}
+			name = snippet.Name
+		} else {
+			if len(rest) < 1 {
+				fmt.Println("Please provide snippet name.")
+				os.Exit(1)
+			}
+			name = rest[0]
+		}
+
+		if fill {
+			filled, err := sm.Fill(name, params)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error filling snippet: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println(filled)
+			return
+		}
+		sm.Show(name)
+	case "run":
+		rest := os.Args[2:]
+		params, rest := parseParamFlags(rest)
+		if len(rest) < 1 {
 			fmt.Println("Please provide snippet name.")
 			os.Exit(1)
 		}
-		sm.Show(os.Args[2])
-	case "delete":
-		if len(os.Args) < 3 {
-			fmt.Println("Please provide snippet name.")
+		if err := sm.Run(rest[0], params); err != nil {
+			if exitErr, ok := err.(*exec.ExitError); ok {
+				os.Exit(exitErr.ExitCode())
+			}
+			fmt.Fprintf(os.Stderr, "Error running snippet: %v\n", err)
+			os.Exit(1)
+		}
+	case "interactive":
+		rest := os.Args[2:]
+		copyFlag, rest := hasFlag(rest, "--copy")
+		editFlag, _ := hasFlag(rest, "--edit")
+
+		snippet, err := sm.SelectInteractive()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error selecting snippet: %v\n", err)
+			os.Exit(1)
+		}
+		if snippet == nil {
+			return
+		}
+
+		switch {
+		case copyFlag:
+			if err := copyToClipboard(snippet.Content); err != nil {
+				fmt.Fprintf(os.Stderr, "Error copying to clipboard: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Snippet '%s' copied to clipboard.\n", snippet.Name)
+		case editFlag:
+			if err := openInMicro(snippet); err != nil {
+				fmt.Fprintf(os.Stderr, "Error opening in micro: %v\n", err)
+				os.Exit(1)
+			}
+		default:
+			sm.Show(snippet.Name)
+		}
+	case "sync":
+		rest := os.Args[2:]
+		force, rest := hasFlag(rest, "--force")
+		if len(rest) < 1 {
+			fmt.Println("Please specify 'upload' or 'download'.")
+			os.Exit(1)
+		}
+		var syncErr error
+		switch rest[0] {
+		case "upload":
+			syncErr = sm.SyncUpload(force)
+		case "download":
+			syncErr = sm.SyncDownload(force)
+		default:
+			fmt.Println("Please specify 'upload' or 'download'.")
+			os.Exit(1)
+		}
+		if syncErr != nil {
+			fmt.Fprintf(os.Stderr, "Error syncing: %v\n", syncErr)
+			os.Exit(1)
+		}
+		fmt.Printf("Sync %s complete.\n", rest[0])
+	case "extract":
+		rest := os.Args[2:]
+		ext, rest := stringFlag(rest, "--extension", "")
+		begin, rest := stringFlag(rest, "--begin", "")
+		end, rest := stringFlag(rest, "--end", "")
+		if len(rest) < 1 {
+			fmt.Println("Please provide at least one file or directory to extract from.")
+			os.Exit(1)
+		}
+		count, err := sm.Extract(rest, ext, begin, end)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error extracting snippets: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Extracted %d snippet(s).\n", count)
+	case "export":
+		rest := os.Args[2:]
+		format, rest := formatFlag(rest)
+		if format == "" || len(rest) < 1 {
+			fmt.Println("Usage: snippetmanager export --format=micro|vscode <outdir>")
 			os.Exit(1)
 		}
-		err := sm.Delete(os.Args[2])
+		if err := sm.Export(rest[0], format); err != nil {
+			fmt.Fprintf(os.Stderr, "Error exporting snippets: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Exported snippets to %s (%s format).\n", rest[0], format)
+	case "import":
+		rest := os.Args[2:]
+		format, rest := formatFlag(rest)
+		if format == "" || len(rest) < 1 {
+			fmt.Println("Usage: snippetmanager import --format=micro|vscode <indir>")
+			os.Exit(1)
+		}
+		count, err := sm.Import(rest[0], format)
 		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error importing snippets: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Imported %d snippet(s) from %s.\n", count, rest[0])
+	case "delete":
+		rest := os.Args[2:]
+		useFilter, rest := hasFlag(rest, "--filter")
+		name := ""
+		if useFilter {
+			snippet, err := sm.SelectInteractive()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error selecting snippet: %v\n", err)
+				os.Exit(1)
+			}
+			if snippet == nil {
+				return
+			}
+			name = snippet.Name
+		} else {
+			if len(rest) < 1 {
+				fmt.Println("Please provide snippet name.")
+				os.Exit(1)
+			}
+			name = rest[0]
+		}
+		if err := sm.Delete(name); err != nil {
 			fmt.Fprintf(os.Stderr, "Error deleting snippet: %v\n", err)
 			os.Exit(1)
 		}
-		fmt.Printf("Snippet '%s' deleted.\n", os.Args[2])
+		fmt.Printf("Snippet '%s' deleted.\n", name)
 	default:
 		printUsage()
 		os.Exit(1)
 	}
-}
\ No newline at end of file
+}
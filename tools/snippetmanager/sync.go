@@ -0,0 +1,365 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// remoteSnippet is the wire format used for both the Gist and GitLab
+// backends: a single JSON blob mapping snippet name to its fields.
+type remoteSnippet struct {
+	Content     string   `json:"content"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Language    string   `json:"language,omitempty"`
+	Output      string   `json:"output,omitempty"`
+	Executable  bool     `json:"executable,omitempty"`
+}
+
+// syncRemote is implemented by each sync backend (Gist, GitLab).
+type syncRemote interface {
+	// Download fetches the current remote snapshot and the time it was
+	// last updated. On any non-success response (including a remote that
+	// doesn't exist yet) it returns a plain error describing the HTTP
+	// status; callers can't currently distinguish "not found" from other
+	// failures.
+	Download() (map[string]remoteSnippet, time.Time, error)
+	// Upload replaces the remote snapshot's content wholesale.
+	Upload(snapshot map[string]remoteSnippet) error
+}
+
+// remote picks the configured sync backend, preferring GitLab when both are
+// configured, and returns nil if neither is set up.
+func (sm *SnippetManager) remote() syncRemote {
+	switch {
+	case sm.Config.GitLab.Token != "" && sm.Config.GitLab.ID != "":
+		return &gitlabRemote{cfg: sm.Config.GitLab}
+	case sm.Config.Gist.AccessToken != "" && sm.Config.Gist.GistID != "":
+		return &gistRemote{cfg: sm.Config.Gist}
+	default:
+		return nil
+	}
+}
+
+// snapshot converts the in-memory snippet map to the wire format.
+func (sm *SnippetManager) snapshot() map[string]remoteSnippet {
+	out := make(map[string]remoteSnippet, len(sm.Snippets))
+	for name, s := range sm.Snippets {
+		out[name] = remoteSnippet{
+			Content:     s.Content,
+			Description: s.Description,
+			Tags:        s.Tags,
+			Language:    s.Language,
+			Output:      s.Output,
+			Executable:  s.Executable,
+		}
+	}
+	return out
+}
+
+// localMtime returns the most recent modification time across every loaded
+// snippet's source file, used to detect sync conflicts.
+func (sm *SnippetManager) localMtime() time.Time {
+	var latest time.Time
+	for _, s := range sm.Snippets {
+		if s.SourceFile == "" {
+			continue
+		}
+		info, err := os.Stat(s.SourceFile)
+		if err != nil {
+			continue
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest
+}
+
+/*
+SyncUpload pushes the local snippet store to the configured remote.
+
+Unless force is set, it refuses to overwrite a remote snapshot whose
+updated_at is newer than the local store's most recent modification time.
+*/
+func (sm *SnippetManager) SyncUpload(force bool) error {
+	remote := sm.remote()
+	if remote == nil {
+		return fmt.Errorf("no sync backend configured (set [Gist] or [GitLab] in snippetmanager.toml)")
+	}
+
+	if !force {
+		_, remoteUpdated, err := remote.Download()
+		if err == nil && remoteUpdated.After(sm.localMtime()) {
+			return fmt.Errorf("remote snippets are newer than local (remote updated %s); use --force to overwrite", remoteUpdated.Format(time.RFC3339))
+		}
+	}
+
+	return remote.Upload(sm.snapshot())
+}
+
+/*
+SyncDownload pulls the remote snapshot and writes each snippet into the
+first writable configured directory, overwriting any existing file for
+that name.
+
+Unless force is set, it refuses to clobber local snippets that are newer
+than the remote snapshot.
+*/
+func (sm *SnippetManager) SyncDownload(force bool) error {
+	remote := sm.remote()
+	if remote == nil {
+		return fmt.Errorf("no sync backend configured (set [Gist] or [GitLab] in snippetmanager.toml)")
+	}
+
+	snapshot, remoteUpdated, err := remote.Download()
+	if err != nil {
+		return err
+	}
+
+	if !force && sm.localMtime().After(remoteUpdated) {
+		return fmt.Errorf("local snippets are newer than remote; use --force to overwrite")
+	}
+
+	dir := sm.firstWritableDirectory()
+	if dir == nil {
+		return fmt.Errorf("no writable snippet directory configured")
+	}
+
+	for name, r := range snapshot {
+		s := &Snippet{
+			Name:        name,
+			Content:     r.Content,
+			Description: r.Description,
+			Tags:        r.Tags,
+			Language:    r.Language,
+			Output:      r.Output,
+			Executable:  r.Executable,
+		}
+		path := filepath.Join(dir.Path, snippetFilename(name, ".toml"))
+		if err := os.WriteFile(path, []byte(s.encode()), 0644); err != nil {
+			return err
+		}
+		s.SourceFile = path
+		sm.Snippets[name] = s
+	}
+
+	return nil
+}
+
+// autoSync pushes to the remote if auto_sync is enabled, logging rather
+// than failing the caller's operation when the push itself fails.
+func (sm *SnippetManager) autoSync() {
+	if !sm.Config.AutoSync {
+		return
+	}
+	if err := sm.SyncUpload(false); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: auto_sync failed: %v\n", err)
+	}
+}
+
+// --- GitHub Gist backend ---
+
+type gistRemote struct {
+	cfg GistConfig
+}
+
+const gistSnippetsFile = "snippets.json"
+
+func (g *gistRemote) Download() (map[string]remoteSnippet, time.Time, error) {
+	req, err := http.NewRequest("GET", "https://api.github.com/gists/"+g.cfg.GistID, nil)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	req.Header.Set("Authorization", "token "+g.cfg.AccessToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, time.Time{}, fmt.Errorf("gist: unexpected status %s: %s", resp.Status, body)
+	}
+
+	var gist struct {
+		UpdatedAt time.Time `json:"updated_at"`
+		Files     map[string]struct {
+			Content string `json:"content"`
+		} `json:"files"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&gist); err != nil {
+		return nil, time.Time{}, err
+	}
+
+	file, ok := gist.Files[gistSnippetsFile]
+	if !ok {
+		return nil, time.Time{}, fmt.Errorf("gist: %s has no %s file", g.cfg.GistID, gistSnippetsFile)
+	}
+
+	var snapshot map[string]remoteSnippet
+	if err := json.Unmarshal([]byte(file.Content), &snapshot); err != nil {
+		return nil, time.Time{}, err
+	}
+	return snapshot, gist.UpdatedAt, nil
+}
+
+func (g *gistRemote) Upload(snapshot map[string]remoteSnippet) error {
+	content, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	payload := struct {
+		Public bool `json:"public"`
+		Files  map[string]struct {
+			Content string `json:"content"`
+		} `json:"files"`
+	}{
+		Public: g.cfg.Public,
+		Files: map[string]struct {
+			Content string `json:"content"`
+		}{
+			gistSnippetsFile: {Content: string(content)},
+		},
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("PATCH", "https://api.github.com/gists/"+g.cfg.GistID, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "token "+g.cfg.AccessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("gist: unexpected status %s: %s", resp.Status, respBody)
+	}
+	return nil
+}
+
+// --- GitLab Snippet backend ---
+
+type gitlabRemote struct {
+	cfg GitLabConfig
+}
+
+func (g *gitlabRemote) baseURL() string {
+	if g.cfg.URL != "" {
+		return g.cfg.URL
+	}
+	return "https://gitlab.com"
+}
+
+func (g *gitlabRemote) Download() (map[string]remoteSnippet, time.Time, error) {
+	metaURL := fmt.Sprintf("%s/api/v4/snippets/%s", g.baseURL(), g.cfg.ID)
+	req, err := http.NewRequest("GET", metaURL, nil)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	req.Header.Set("PRIVATE-TOKEN", g.cfg.Token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, time.Time{}, fmt.Errorf("gitlab: unexpected status %s: %s", resp.Status, body)
+	}
+
+	var meta struct {
+		UpdatedAt time.Time `json:"updated_at"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&meta); err != nil {
+		return nil, time.Time{}, err
+	}
+
+	rawURL := fmt.Sprintf("%s/api/v4/snippets/%s/raw", g.baseURL(), g.cfg.ID)
+	rawReq, err := http.NewRequest("GET", rawURL, nil)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	rawReq.Header.Set("PRIVATE-TOKEN", g.cfg.Token)
+
+	rawResp, err := http.DefaultClient.Do(rawReq)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	defer rawResp.Body.Close()
+
+	rawBody, err := io.ReadAll(rawResp.Body)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	var snapshot map[string]remoteSnippet
+	if err := json.Unmarshal(rawBody, &snapshot); err != nil {
+		return nil, time.Time{}, err
+	}
+	return snapshot, meta.UpdatedAt, nil
+}
+
+func (g *gitlabRemote) Upload(snapshot map[string]remoteSnippet) error {
+	content, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	visibility := g.cfg.Visibility
+	if visibility == "" {
+		visibility = "private"
+	}
+
+	payload := struct {
+		Content    string `json:"content"`
+		Visibility string `json:"visibility"`
+	}{
+		Content:    string(content),
+		Visibility: visibility,
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/api/v4/snippets/%s", g.baseURL(), g.cfg.ID)
+	req, err := http.NewRequest("PUT", url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("PRIVATE-TOKEN", g.cfg.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("gitlab: unexpected status %s: %s", resp.Status, respBody)
+	}
+	return nil
+}
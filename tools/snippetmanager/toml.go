@@ -0,0 +1,211 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// tomlDoc is the result of parsing a TOML file: top-level key/value pairs
+// plus any array-of-tables sections (`[[name]]`) keyed by section name.
+type tomlDoc struct {
+	Top    map[string]interface{}
+	Tables map[string][]map[string]interface{}
+}
+
+/*
+parseTOML decodes a small, practical subset of TOML: top-level key = value
+pairs, array-of-tables sections ([[name]]), string/bool/int/array-of-string
+values, and triple-quoted ('''...''') strings that may span multiple lines.
+
+This is not a general-purpose TOML implementation. It covers exactly what
+the snippet manager's config and snippet files need, so it has no external
+dependency to vendor.
+*/
+func parseTOML(data []byte) (*tomlDoc, error) {
+	doc := &tomlDoc{
+		Top:    make(map[string]interface{}),
+		Tables: make(map[string][]map[string]interface{}),
+	}
+
+	lines := strings.Split(string(data), "\n")
+	var current map[string]interface{} = doc.Top
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[[") && strings.HasSuffix(line, "]]") {
+			name := strings.TrimSpace(line[2 : len(line)-2])
+			entry := make(map[string]interface{})
+			doc.Tables[name] = append(doc.Tables[name], entry)
+			current = entry
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			table := make(map[string]interface{})
+			doc.Top[name] = table
+			current = table
+			continue
+		}
+
+		eq := strings.Index(line, "=")
+		if eq < 0 {
+			return nil, fmt.Errorf("toml: line %d: expected 'key = value', got %q", i+1, line)
+		}
+		key := strings.TrimSpace(line[:eq])
+		rawVal := strings.TrimSpace(line[eq+1:])
+
+		if strings.HasPrefix(rawVal, "'''") {
+			value, consumed, err := parseMultilineString(lines, i, rawVal)
+			if err != nil {
+				return nil, err
+			}
+			current[key] = value
+			i = consumed
+			continue
+		}
+
+		val, err := parseTOMLValue(rawVal)
+		if err != nil {
+			return nil, fmt.Errorf("toml: line %d: %v", i+1, err)
+		}
+		current[key] = val
+	}
+
+	return doc, nil
+}
+
+// parseMultilineString consumes lines starting at startIdx (whose first line
+// is rawVal, beginning with ''') until the closing ''' is found. It returns
+// the decoded string and the index of the last consumed line.
+func parseMultilineString(lines []string, startIdx int, rawVal string) (string, int, error) {
+	body := strings.TrimPrefix(rawVal, "'''")
+	if end := strings.Index(body, "'''"); end >= 0 {
+		return body[:end], startIdx, nil
+	}
+
+	var out []string
+	out = append(out, body)
+	for i := startIdx + 1; i < len(lines); i++ {
+		if end := strings.Index(lines[i], "'''"); end >= 0 {
+			out = append(out, lines[i][:end])
+			return strings.Join(out, "\n"), i, nil
+		}
+		out = append(out, lines[i])
+	}
+	return "", len(lines) - 1, fmt.Errorf("toml: unterminated ''' string starting near %q", rawVal)
+}
+
+func parseTOMLValue(raw string) (interface{}, error) {
+	switch {
+	case raw == "true":
+		return true, nil
+	case raw == "false":
+		return false, nil
+	case strings.HasPrefix(raw, "[") && strings.HasSuffix(raw, "]"):
+		inner := strings.TrimSpace(raw[1 : len(raw)-1])
+		if inner == "" {
+			return []string{}, nil
+		}
+		parts := splitTOMLArray(inner)
+		out := make([]string, 0, len(parts))
+		for _, p := range parts {
+			s, err := unquoteTOMLString(strings.TrimSpace(p))
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, s)
+		}
+		return out, nil
+	case strings.HasPrefix(raw, "\""):
+		return unquoteTOMLString(raw)
+	default:
+		if n, err := strconv.Atoi(raw); err == nil {
+			return n, nil
+		}
+		return nil, fmt.Errorf("unsupported value %q", raw)
+	}
+}
+
+// splitTOMLArray splits a comma-separated inline array body, ignoring commas
+// that appear inside quoted strings.
+func splitTOMLArray(inner string) []string {
+	var parts []string
+	var b strings.Builder
+	inQuotes := false
+	for i := 0; i < len(inner); i++ {
+		c := inner[i]
+		switch c {
+		case '"':
+			inQuotes = !inQuotes
+			b.WriteByte(c)
+		case ',':
+			if inQuotes {
+				b.WriteByte(c)
+			} else {
+				parts = append(parts, b.String())
+				b.Reset()
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	if strings.TrimSpace(b.String()) != "" {
+		parts = append(parts, b.String())
+	}
+	return parts
+}
+
+func unquoteTOMLString(raw string) (string, error) {
+	if len(raw) < 2 || raw[0] != '"' || raw[len(raw)-1] != '"' {
+		return "", fmt.Errorf("expected quoted string, got %q", raw)
+	}
+	unquoted, err := strconv.Unquote(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid string %q: %v", raw, err)
+	}
+	return unquoted, nil
+}
+
+// quoteTOMLString renders s as a double-quoted TOML string with Go-style
+// escapes. Multi-line content is still written on one logical line (\n
+// escaped rather than literal), which keeps this format collision-free:
+// unlike a ''' delimiter, a double-quoted string can always represent any
+// input verbatim, including content that itself contains '''.
+func quoteTOMLString(s string) string {
+	return strconv.Quote(s)
+}
+
+func quoteTOMLStringArray(items []string) string {
+	quoted := make([]string, len(items))
+	for i, it := range items {
+		quoted[i] = strconv.Quote(it)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+func tomlString(m map[string]interface{}, key string) string {
+	if v, ok := m[key].(string); ok {
+		return v
+	}
+	return ""
+}
+
+func tomlStringSlice(m map[string]interface{}, key string) []string {
+	if v, ok := m[key].([]string); ok {
+		return v
+	}
+	return nil
+}
+
+func tomlBool(m map[string]interface{}, key string) bool {
+	if v, ok := m[key].(bool); ok {
+		return v
+	}
+	return false
+}